@@ -0,0 +1,84 @@
+// Package analysis lets callers register Analyzer functions and run
+// them across the packages returned by inspect.ParsePackagesFromDir,
+// turning inspect from a passive AST dumper into an extensible
+// static-analysis platform.
+package analysis
+
+import "github.com/radovskyb/inspect"
+
+// Severity describes how serious a Diagnostic is.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+// String returns the lower-case name of the severity level.
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// MarshalJSON encodes s as its String form rather than its integer
+// value, so reports stay readable without a lookup table.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+// A Diagnostic is a single finding reported by an Analyzer.
+type Diagnostic struct {
+	// Pos is the "file:line:column" position the diagnostic refers to, taken
+	// directly from the inspect.Function/Interface/Struct it was
+	// raised against.
+	Pos string `json:"pos"`
+
+	Message  string   `json:"message"`
+	Severity Severity `json:"severity"`
+}
+
+// An Analyzer inspects a single *inspect.Package and reports the
+// Diagnostics it finds.
+type Analyzer struct {
+	Name string
+	Doc  string
+	Run  func(pkg *inspect.Package) []Diagnostic
+}
+
+// A Result groups the Diagnostics an Analyzer reported against a
+// single package, identified by its import path.
+type Result struct {
+	Package     string       `json:"package"`
+	Analyzer    string       `json:"analyzer"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// Run executes every analyzer in analyzers against every package in
+// pkgs, returning one Result per (package, analyzer) pair that
+// produced at least one Diagnostic.
+func Run(pkgs map[string]*inspect.Package, analyzers []*Analyzer) []Result {
+	var results []Result
+
+	for path, pkg := range pkgs {
+		for _, a := range analyzers {
+			diags := a.Run(pkg)
+			if len(diags) == 0 {
+				continue
+			}
+			results = append(results, Result{
+				Package:     path,
+				Analyzer:    a.Name,
+				Diagnostics: diags,
+			})
+		}
+	}
+
+	return results
+}
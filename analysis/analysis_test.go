@@ -0,0 +1,81 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/radovskyb/inspect"
+)
+
+func TestRunExportedFuncMissingDoc(t *testing.T) {
+	pkgs, err := inspect.ParsePackagesFromDir("../testfiles/structs", true, inspect.FuncBoth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := Run(pkgs, []*Analyzer{ExportedFuncMissingDoc})
+	for _, res := range results {
+		for _, d := range res.Diagnostics {
+			t.Errorf("unexpected diagnostic: %+v", d)
+		}
+	}
+}
+
+func TestRunInterfaceWithNoImplementers_ASTOnly(t *testing.T) {
+	pkgs, err := inspect.ParsePackagesFromDir("../testfiles/structs", true, inspect.FuncBoth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without type information, the analyzer can't tell a genuinely
+	// unimplemented interface from one it simply has no data on, so it
+	// must report nothing rather than flagging every interface.
+	results := Run(pkgs, []*Analyzer{InterfaceWithNoImplementers})
+	for _, res := range results {
+		for _, d := range res.Diagnostics {
+			t.Errorf("unexpected diagnostic on an AST-only package: %+v", d)
+		}
+	}
+}
+
+func TestRunInterfaceWithNoImplementers_TypeChecked(t *testing.T) {
+	pkgs, err := inspect.ParsePackagesTypeChecked("../testfiles/structs", inspect.TypeCheckOptions{
+		IgnoreTests: true,
+		FuncOption:  inspect.FuncBoth,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reader is implemented by Widget in the same package, so a
+	// type-checked run must not flag it, but Loner has no implementer
+	// and must be.
+	results := Run(pkgs, []*Analyzer{InterfaceWithNoImplementers})
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	if len(results[0].Diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(results[0].Diagnostics), results[0].Diagnostics)
+	}
+
+	got := results[0].Diagnostics[0]
+	if !strings.Contains(got.Message, "Loner") {
+		t.Errorf("expected diagnostic about Loner, got %+v", got)
+	}
+	if strings.Contains(got.Message, "Reader") {
+		t.Errorf("Reader is implemented by Widget and should not be flagged, got %+v", got)
+	}
+}
+
+func TestComplexityThreshold(t *testing.T) {
+	pkgs, err := inspect.ParsePackagesFromDir("../testfiles/structs", true, inspect.FuncBoth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := Run(pkgs, []*Analyzer{ComplexityThreshold(0)})
+	if len(results) == 0 {
+		t.Fatal("expected at least one result with a zero threshold")
+	}
+}
@@ -0,0 +1,133 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+
+	"github.com/radovskyb/inspect"
+)
+
+// ExportedFuncMissingDoc flags every exported function that has no doc
+// comment.
+var ExportedFuncMissingDoc = &Analyzer{
+	Name: "exported-function-missing-doc",
+	Doc:  "reports exported functions with no doc comment",
+	Run: func(pkg *inspect.Package) []Diagnostic {
+		var diags []Diagnostic
+		for _, fn := range pkg.Funcs {
+			if fn.IsExported() && fn.Documentation == "" {
+				diags = append(diags, Diagnostic{
+					Pos:      fn.Pos,
+					Message:  fmt.Sprintf("exported function %s has no doc comment", fn.Name),
+					Severity: Warning,
+				})
+			}
+		}
+		return diags
+	},
+}
+
+// InterfaceWithNoImplementers flags interfaces with no known
+// implementer in the same package. It relies on pkg.Implementers,
+// which is only populated for packages parsed by
+// inspect.ParsePackagesTypeChecked; against an AST-only package,
+// pkg.Implementers would always return nil, so this analyzer reports
+// nothing rather than flagging every interface as a false positive.
+var InterfaceWithNoImplementers = &Analyzer{
+	Name: "interface-with-no-implementers",
+	Doc:  "reports interfaces with no implementers found in the same package (requires ParsePackagesTypeChecked)",
+	Run: func(pkg *inspect.Package) []Diagnostic {
+		if !pkg.TypeChecked() {
+			return nil
+		}
+
+		var diags []Diagnostic
+		for _, iface := range pkg.Interfaces {
+			if len(pkg.Implementers(iface)) == 0 {
+				diags = append(diags, Diagnostic{
+					Pos:      iface.Pos,
+					Message:  fmt.Sprintf("interface %s has no implementers in this package", iface.Name),
+					Severity: Info,
+				})
+			}
+		}
+		return diags
+	},
+}
+
+// identRe matches a single Go identifier, used to pull type names out
+// of a printed function signature.
+var identRe = regexp.MustCompile(`\b[A-Za-z_][A-Za-z0-9_]*\b`)
+
+// UnexportedSymbolLeaked flags exported functions whose signature
+// mentions an unexported struct or interface declared in the same
+// package, since callers outside the package can't name that type.
+var UnexportedSymbolLeaked = &Analyzer{
+	Name: "unexported-symbol-leaked-in-exported-signature",
+	Doc:  "reports exported functions whose signature references an unexported package-local type",
+	Run: func(pkg *inspect.Package) []Diagnostic {
+		unexported := make(map[string]bool)
+		for _, s := range pkg.Structs {
+			if !ast.IsExported(s.Name) {
+				unexported[s.Name] = true
+			}
+		}
+		for _, i := range pkg.Interfaces {
+			if !ast.IsExported(i.Name) {
+				unexported[i.Name] = true
+			}
+		}
+		if len(unexported) == 0 {
+			return nil
+		}
+
+		var diags []Diagnostic
+		for _, fn := range pkg.Funcs {
+			if !fn.IsExported() {
+				continue
+			}
+			for _, ident := range identRe.FindAllString(fn.Signature, -1) {
+				if unexported[ident] {
+					diags = append(diags, Diagnostic{
+						Pos:      fn.Pos,
+						Message:  fmt.Sprintf("exported function %s leaks unexported type %s in its signature", fn.Name, ident),
+						Severity: Error,
+					})
+				}
+			}
+		}
+		return diags
+	},
+}
+
+// ComplexityThreshold returns an Analyzer that flags functions whose
+// Complexity exceeds threshold.
+func ComplexityThreshold(threshold int) *Analyzer {
+	return &Analyzer{
+		Name: "cyclomatic-complexity-over-threshold",
+		Doc:  fmt.Sprintf("reports functions with cyclomatic complexity over %d", threshold),
+		Run: func(pkg *inspect.Package) []Diagnostic {
+			var diags []Diagnostic
+			for _, fn := range pkg.Funcs {
+				if fn.Complexity > threshold {
+					diags = append(diags, Diagnostic{
+						Pos:      fn.Pos,
+						Message:  fmt.Sprintf("function %s has cyclomatic complexity %d, over threshold %d", fn.Name, fn.Complexity, threshold),
+						Severity: Warning,
+					})
+				}
+			}
+			return diags
+		},
+	}
+}
+
+// Default is the built-in analyzer set, using a complexity threshold
+// of 10.
+var Default = []*Analyzer{
+	ExportedFuncMissingDoc,
+	InterfaceWithNoImplementers,
+	UnexportedSymbolLeaked,
+	ComplexityThreshold(10),
+}
@@ -0,0 +1,28 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteJSON encodes results as indented JSON to w.
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(results)
+}
+
+// WriteText writes results to w as one "pos: [severity] analyzer: message"
+// line per diagnostic.
+func WriteText(w io.Writer, results []Result) error {
+	for _, res := range results {
+		for _, d := range res.Diagnostics {
+			_, err := fmt.Fprintf(w, "%s: [%s] %s: %s\n", d.Pos, d.Severity, res.Analyzer, d.Message)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
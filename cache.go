@@ -0,0 +1,91 @@
+package inspect
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache stores and retrieves parsed Package fragments keyed by a
+// content hash, letting repeated calls to a cached parse function skip
+// rebuilding the fragment of a file whose content hasn't changed. Every
+// file is still parsed into an *ast.File on every call; only the
+// fragment is served from the cache. A fragment is the
+// result of parsing a single file, keyed by that file's content hash
+// combined with the FuncOption it was parsed with.
+type Cache interface {
+	// Get returns the cached fragment for key, and whether it was found.
+	Get(key string) (*Package, bool)
+
+	// Put stores fragment under key.
+	Put(key string, fragment *Package) error
+}
+
+// DiskCache is a Cache backed by one gob file per key under Dir. It's
+// safe for concurrent use.
+type DiskCache struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+// NewDiskCache returns a DiskCache rooted at dir, creating dir if it
+// doesn't exist. If dir is empty, it defaults to
+// $XDG_CACHE_HOME/inspect, falling back to $HOME/.cache/inspect.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if dir == "" {
+		base := os.Getenv("XDG_CACHE_HOME")
+		if base == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, err
+			}
+			base = filepath.Join(home, ".cache")
+		}
+		dir = filepath.Join(base, "inspect")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &DiskCache{Dir: dir}, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".gob")
+}
+
+// Get implements Cache.
+func (c *DiskCache) Get(key string) (*Package, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, err := os.Open(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	var frag Package
+	if err := gob.NewDecoder(f).Decode(&frag); err != nil {
+		return nil, false
+	}
+
+	return &frag, true
+}
+
+// Put implements Cache.
+func (c *DiskCache) Put(key string, fragment *Package) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, err := os.Create(c.path(key))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return gob.NewEncoder(f).Encode(fragment)
+}
@@ -0,0 +1,49 @@
+// Command inspectlint runs the built-in inspect/analysis analyzers
+// against a directory tree and reports what it finds.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/radovskyb/inspect"
+	"github.com/radovskyb/inspect/analysis"
+)
+
+func main() {
+	jsonOutput := flag.Bool("json", false, "emit JSON instead of text")
+	threshold := flag.Int("complexity", 10, "cyclomatic complexity threshold")
+	ignoreTests := flag.Bool("ignore-tests", true, "ignore _test.go files")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	pkgs, err := inspect.ParsePackagesFromDir(dir, *ignoreTests, inspect.FuncBoth)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// InterfaceWithNoImplementers is omitted here: it needs a package
+	// parsed by inspect.ParsePackagesTypeChecked, and this binary only
+	// runs the AST-only ParsePackagesFromDir.
+	analyzers := append([]*analysis.Analyzer{
+		analysis.ExportedFuncMissingDoc,
+		analysis.UnexportedSymbolLeaked,
+	}, analysis.ComplexityThreshold(*threshold))
+
+	results := analysis.Run(pkgs, analyzers)
+
+	var writeErr error
+	if *jsonOutput {
+		writeErr = analysis.WriteJSON(os.Stdout, results)
+	} else {
+		writeErr = analysis.WriteText(os.Stdout, results)
+	}
+	if writeErr != nil {
+		log.Fatalln(writeErr)
+	}
+}
@@ -26,8 +26,14 @@ func main() {
 		log.Fatalln(err)
 	}
 
-	// Delete any non-library, main package's.
-	delete(pkgs, "main")
+	// Delete any non-library, main package's. Packages are now keyed by
+	// import path rather than package name, so "main" packages have to
+	// be identified by their Name field instead of a map lookup.
+	for path, pkg := range pkgs {
+		if pkg.Name == "main" {
+			delete(pkgs, path)
+		}
+	}
 
 	// Package name to interface.
 	ifaces := map[string][]*inspect.Interface{}
@@ -27,8 +27,14 @@ func main() {
 		log.Fatalln(err)
 	}
 
-	// Delete any non-library, main package's.
-	delete(pkgs, "main")
+	// Delete any non-library, main package's. Packages are now keyed by
+	// import path rather than package name, so "main" packages have to
+	// be identified by their Name field instead of a map lookup.
+	for path, pkg := range pkgs {
+		if pkg.Name == "main" {
+			delete(pkgs, path)
+		}
+	}
 
 	// Create a new json file to store all of Go's standard package library info.
 	jsonFile, err := os.Create("packages.json")
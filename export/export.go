@@ -0,0 +1,193 @@
+// Package export turns parsed inspect.Package data into
+// machine-readable schemas for other tools to generate code from:
+// protobuf .proto files, GraphQL SDL, and a minimal OpenAPI 3 /
+// JSON-Schema document.
+package export
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A Mapper translates a Go type name, as it appears in a parsed
+// signature or struct field, into the equivalent type in a target
+// schema language. Each encoder in this package has a default Mapper
+// covering the common scalar types; pass a custom one to override
+// specific types (e.g. mapping "time.Time" to
+// "google.protobuf.Timestamp" for ProtoFile).
+type Mapper interface {
+	MapType(goType string) string
+}
+
+// MapperFunc adapts a plain function to the Mapper interface.
+type MapperFunc func(goType string) string
+
+// MapType implements Mapper.
+func (f MapperFunc) MapType(goType string) string {
+	return f(goType)
+}
+
+// field is a single parsed parameter or result, synthesizing a name
+// when the original signature didn't name it.
+type field struct {
+	name string
+	typ  string
+}
+
+// sigNameRe matches the "func Name" prefix of a "func Name(params)
+// results" signature, as produced by ParseFileInterfaces or
+// ParseFunction.
+var sigNameRe = regexp.MustCompile(`^func\s+(\w+)\s*\(`)
+
+// parseSignature extracts name, params and results from sig. It
+// returns an error if sig doesn't look like a plain "func Name(...) ..."
+// signature, e.g. because it still carries a method receiver.
+//
+// The parameter list is located with splitTopLevel's depth tracking
+// rather than a regex matched up to the first ")", so a parameter with
+// a func-typed or otherwise parenthesized type, e.g. "Do(f func() error)",
+// doesn't get cut off at its first nested paren.
+func parseSignature(sig string) (name string, params, results []field, err error) {
+	sig = strings.TrimSpace(sig)
+
+	m := sigNameRe.FindStringSubmatch(sig)
+	if m == nil {
+		return "", nil, nil, fmt.Errorf("export: can't parse signature %q", sig)
+	}
+	name = m[1]
+
+	openIdx := len(m[0]) - 1
+	closeIdx := matchingParen(sig, openIdx)
+	if closeIdx == -1 {
+		return "", nil, nil, fmt.Errorf("export: can't parse signature %q", sig)
+	}
+
+	params = parseFieldList(sig[openIdx+1:closeIdx], "arg")
+	results = parseFieldList(strings.Trim(strings.TrimSpace(sig[closeIdx+1:]), "()"), "result")
+
+	return name, params, results, nil
+}
+
+// matchingParen returns the index within s of the ')' that closes the
+// '(' at index open, or -1 if s[open] isn't '(' or it's never closed.
+func matchingParen(s string, open int) int {
+	if open >= len(s) || s[open] != '(' {
+		return -1
+	}
+
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// parseFieldList splits a parameter or result list such as
+// "a int, b string" or "string, error" into fields, synthesizing a
+// "<prefix><n>" name for any entry that didn't name its parameter.
+//
+// Go's grouped-parameter grammar lets several names share a single
+// type, e.g. "a, b int" means both a and b are int: every entry up to
+// the first one that carries a type borrows that type. parseFieldList
+// accounts for this by buffering name-only entries until it reaches
+// one with a type, rather than treating a lone name as its own type.
+func parseFieldList(list, prefix string) []field {
+	list = strings.TrimSpace(list)
+	if list == "" {
+		return nil
+	}
+
+	var fields []field
+	var pendingNames []string
+	n := 0
+
+	flushUntyped := func() {
+		for _, name := range pendingNames {
+			n++
+			fields = append(fields, field{name: fmt.Sprintf("%s%d", prefix, n), typ: name})
+		}
+		pendingNames = nil
+	}
+
+	for _, part := range splitTopLevel(list) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		words := strings.Fields(part)
+		if len(words) == 1 {
+			pendingNames = append(pendingNames, words[0])
+			continue
+		}
+
+		typ := strings.Join(words[1:], " ")
+		for _, name := range pendingNames {
+			fields = append(fields, field{name: name, typ: typ})
+		}
+		pendingNames = nil
+
+		fields = append(fields, field{name: words[0], typ: typ})
+	}
+
+	// Any names left over never found a type to borrow, meaning they
+	// were actually bare, unnamed types (e.g. a result list like
+	// "string, error"), not parameter names.
+	flushUntyped()
+
+	return fields
+}
+
+// splitTopLevel splits s on commas that aren't nested inside brackets
+// or parens, so a parameter type like "map[string]int" or
+// "func(a, b)" doesn't get split apart.
+func splitTopLevel(s string) []string {
+	var parts []string
+
+	depth, last := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[last:])
+
+	return parts
+}
+
+// isExportedName reports whether name is an exported Go identifier.
+func isExportedName(name string) bool {
+	return ast.IsExported(name)
+}
+
+// lowerFirst returns s with its first rune lower-cased, used to turn a
+// Go type or field name into the camelCase convention GraphQL and
+// JSON typically use.
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
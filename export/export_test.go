@@ -0,0 +1,121 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/radovskyb/inspect"
+)
+
+func loadStructsPkg(t *testing.T) *inspect.Package {
+	t.Helper()
+
+	pkgs, err := inspect.ParsePackagesFromDir("../testfiles/structs", true, inspect.FuncBoth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, ok := pkgs["."]
+	if !ok {
+		t.Fatal("expected testfiles/structs to be keyed by \".\"")
+	}
+
+	return pkg
+}
+
+func TestParseFieldListGroupedParams(t *testing.T) {
+	fields := parseFieldList("a, b int, c string", "arg")
+
+	want := []field{
+		{name: "a", typ: "int"},
+		{name: "b", typ: "int"},
+		{name: "c", typ: "string"},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("expected %d fields, got %d: %+v", len(want), len(fields), fields)
+	}
+	for i, w := range want {
+		if fields[i] != w {
+			t.Errorf("field %d: expected %+v, got %+v", i, w, fields[i])
+		}
+	}
+}
+
+func TestParseSignatureFuncTypedParam(t *testing.T) {
+	name, params, results, err := parseSignature("func Do(f func() error) error")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "Do" {
+		t.Errorf("expected name Do, got %q", name)
+	}
+
+	if len(params) != 1 || params[0].name != "f" || params[0].typ != "func() error" {
+		t.Errorf("expected a single f func() error param, got %+v", params)
+	}
+
+	if len(results) != 1 || results[0].typ != "error" {
+		t.Errorf("expected a single error result, got %+v", results)
+	}
+}
+
+func TestProtoFile(t *testing.T) {
+	pkg := loadStructsPkg(t)
+
+	proto, err := ProtoFile(pkg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"service Reader {",
+		"rpc Read (ReaderReadRequest) returns (ReaderReadResponse);",
+		"message ReaderReadRequest {",
+		"message ReaderReadResponse {",
+	} {
+		if !strings.Contains(proto, want) {
+			t.Errorf("expected proto output to contain %q, got:\n%s", want, proto)
+		}
+	}
+}
+
+func TestGraphQLSchema(t *testing.T) {
+	pkg := loadStructsPkg(t)
+
+	schema := GraphQLSchema(pkg, nil)
+
+	for _, want := range []string{
+		"type Widget {",
+		"name: String",
+		"count: Int",
+		"type Labeled {",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("expected schema to contain %q, got:\n%s", want, schema)
+		}
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	pkg := loadStructsPkg(t)
+
+	spec := OpenAPIDocument(pkg, "demo", "v1", nil)
+
+	widget, ok := spec.Components.Schemas["Widget"]
+	if !ok {
+		t.Fatal("expected a Widget schema")
+	}
+
+	if widget.Properties["Name"].Type != "string" {
+		t.Errorf("expected Widget.Name to map to string, got %q", widget.Properties["Name"].Type)
+	}
+
+	if widget.Properties["Count"].Type != "integer" {
+		t.Errorf("expected Widget.Count to map to integer, got %q", widget.Properties["Count"].Type)
+	}
+
+	if _, err := spec.JSON(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,91 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/radovskyb/inspect"
+)
+
+// graphqlScalars maps common Go scalar type names to their GraphQL
+// equivalents. Anything not in this table is passed through
+// unchanged, on the assumption that it already names an object type
+// produced elsewhere in the same schema.
+var graphqlScalars = map[string]string{
+	"int": "Int", "int32": "Int", "int64": "Int",
+	"float32": "Float", "float64": "Float",
+	"string": "String",
+	"bool":   "Boolean",
+}
+
+// DefaultGraphQLMapper maps Go scalar types to their GraphQL
+// equivalents, stripping a leading pointer.
+var DefaultGraphQLMapper Mapper = MapperFunc(func(t string) string {
+	t = strings.TrimPrefix(t, "*")
+	if g, ok := graphqlScalars[t]; ok {
+		return g
+	}
+	return t
+})
+
+// GraphQLSchema renders pkg's exported structs as GraphQL object
+// types, and its exported functions as queries on a single Query type.
+// Unexported fields and functions are skipped.
+//
+// If mapper is nil, DefaultGraphQLMapper is used.
+func GraphQLSchema(pkg *inspect.Package, mapper Mapper) string {
+	if mapper == nil {
+		mapper = DefaultGraphQLMapper
+	}
+
+	var sb strings.Builder
+
+	for _, s := range pkg.Structs {
+		if !isExportedName(s.Name) {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "type %s {\n", s.Name)
+		for _, f := range s.Fields {
+			if !isExportedName(f.Name) {
+				continue
+			}
+			fmt.Fprintf(&sb, "  %s: %s\n", lowerFirst(f.Name), mapper.MapType(f.Type))
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	sb.WriteString("type Query {\n")
+	for _, fn := range pkg.Funcs {
+		if !fn.IsExported() {
+			continue
+		}
+
+		_, params, results, err := parseSignature(fn.Signature)
+		if err != nil {
+			// Most likely a method, which still carries its receiver in
+			// Signature; queries only make sense for plain functions.
+			continue
+		}
+
+		args := make([]string, 0, len(params))
+		for _, p := range params {
+			args = append(args, fmt.Sprintf("%s: %s", p.name, mapper.MapType(p.typ)))
+		}
+
+		ret := "Boolean"
+		if len(results) > 0 {
+			ret = mapper.MapType(results[0].typ)
+		}
+
+		var argList string
+		if len(args) > 0 {
+			argList = "(" + strings.Join(args, ", ") + ")"
+		}
+
+		fmt.Fprintf(&sb, "  %s%s: %s\n", lowerFirst(fn.Name), argList, ret)
+	}
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
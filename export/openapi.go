@@ -0,0 +1,105 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/radovskyb/inspect"
+)
+
+// jsonSchemaScalars maps common Go scalar type names to their
+// JSON-Schema equivalents. Anything not in this table maps to
+// "object", on the assumption that it names another of pkg's structs.
+var jsonSchemaScalars = map[string]string{
+	"int": "integer", "int32": "integer", "int64": "integer",
+	"float32": "number", "float64": "number",
+	"string": "string",
+	"bool":   "boolean",
+}
+
+// DefaultJSONSchemaMapper maps Go scalar types to their JSON-Schema
+// equivalents, stripping a leading pointer.
+var DefaultJSONSchemaMapper Mapper = MapperFunc(func(t string) string {
+	t = strings.TrimPrefix(t, "*")
+	if s, ok := jsonSchemaScalars[t]; ok {
+		return s
+	}
+	return "object"
+})
+
+// JSONSchemaProperty is a single property of a JSONSchema object.
+type JSONSchemaProperty struct {
+	Type string `json:"type"`
+}
+
+// JSONSchema is a minimal JSON-Schema object, just enough to describe
+// one of pkg's exported structs.
+type JSONSchema struct {
+	Type       string                        `json:"type"`
+	Properties map[string]JSONSchemaProperty `json:"properties,omitempty"`
+}
+
+// OpenAPIInfo is the "info" object of an OpenAPISpec.
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIComponents is the "components" object of an OpenAPISpec.
+type OpenAPIComponents struct {
+	Schemas map[string]JSONSchema `json:"schemas"`
+}
+
+// OpenAPISpec is a minimal OpenAPI 3 document containing component
+// schemas for pkg's exported structs. Paths is left empty for callers
+// to fill in themselves; this package only describes data shapes, not
+// routes.
+type OpenAPISpec struct {
+	OpenAPI    string                 `json:"openapi"`
+	Info       OpenAPIInfo            `json:"info"`
+	Paths      map[string]interface{} `json:"paths"`
+	Components OpenAPIComponents      `json:"components"`
+}
+
+// OpenAPIDocument builds an OpenAPISpec describing pkg's exported
+// structs as component schemas, titled title at version.
+//
+// If mapper is nil, DefaultJSONSchemaMapper is used.
+func OpenAPIDocument(pkg *inspect.Package, title, version string, mapper Mapper) OpenAPISpec {
+	if mapper == nil {
+		mapper = DefaultJSONSchemaMapper
+	}
+
+	schemas := make(map[string]JSONSchema)
+	for _, s := range pkg.Structs {
+		if !isExportedName(s.Name) {
+			continue
+		}
+
+		props := make(map[string]JSONSchemaProperty)
+		for _, f := range s.Fields {
+			if !isExportedName(f.Name) {
+				continue
+			}
+			props[f.Name] = JSONSchemaProperty{Type: mapper.MapType(f.Type)}
+		}
+
+		schemas[s.Name] = JSONSchema{Type: "object", Properties: props}
+	}
+
+	return OpenAPISpec{
+		OpenAPI:    "3.0.3",
+		Info:       OpenAPIInfo{Title: title, Version: version},
+		Paths:      map[string]interface{}{},
+		Components: OpenAPIComponents{Schemas: schemas},
+	}
+}
+
+// JSON renders spec as indented JSON.
+func (spec OpenAPISpec) JSON() (string, error) {
+	data, err := json.MarshalIndent(spec, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/radovskyb/inspect"
+)
+
+// protoScalars maps common Go scalar type names to their proto3
+// equivalents. Anything not in this table is passed through unchanged,
+// on the assumption that it already names a message produced
+// elsewhere in the same .proto file.
+var protoScalars = map[string]string{
+	"int":     "int64",
+	"int32":   "int32",
+	"int64":   "int64",
+	"uint":    "uint64",
+	"uint32":  "uint32",
+	"uint64":  "uint64",
+	"float32": "float",
+	"float64": "double",
+	"string":  "string",
+	"bool":    "bool",
+	"byte":    "uint32",
+	"error":   "string",
+	"[]byte":  "bytes",
+}
+
+// DefaultProtoMapper maps Go scalar types to their proto3 equivalents.
+var DefaultProtoMapper Mapper = MapperFunc(func(t string) string {
+	if p, ok := protoScalars[t]; ok {
+		return p
+	}
+	return t
+})
+
+// ProtoFile renders pkg as a .proto file: each Interface becomes a
+// service, and each of its methods becomes an rpc with a synthesized
+// request and response message named <Interface><Method>Request and
+// <Interface><Method>Response.
+//
+// If mapper is nil, DefaultProtoMapper is used.
+func ProtoFile(pkg *inspect.Package, mapper Mapper) (string, error) {
+	if mapper == nil {
+		mapper = DefaultProtoMapper
+	}
+
+	var services, messages strings.Builder
+	fmt.Fprintf(&services, "syntax = \"proto3\";\n\npackage %s;\n\n", pkg.Name)
+
+	for _, iface := range pkg.Interfaces {
+		fmt.Fprintf(&services, "service %s {\n", iface.Name)
+
+		for _, method := range iface.Methods {
+			name, params, results, err := parseSignature(method)
+			if err != nil {
+				return "", err
+			}
+
+			reqName := iface.Name + name + "Request"
+			respName := iface.Name + name + "Response"
+
+			fmt.Fprintf(&services, "  rpc %s (%s) returns (%s);\n", name, reqName, respName)
+
+			writeProtoMessage(&messages, reqName, params, mapper)
+			writeProtoMessage(&messages, respName, results, mapper)
+		}
+
+		services.WriteString("}\n\n")
+	}
+
+	return services.String() + messages.String(), nil
+}
+
+func writeProtoMessage(sb *strings.Builder, name string, fields []field, mapper Mapper) {
+	fmt.Fprintf(sb, "message %s {\n", name)
+	for i, f := range fields {
+		fmt.Fprintf(sb, "  %s %s = %d;\n", mapper.MapType(f.typ), f.name, i+1)
+	}
+	sb.WriteString("}\n\n")
+}
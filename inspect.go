@@ -4,11 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
-	"go/parser"
 	"go/printer"
 	"go/token"
+	"go/types"
 	"os"
-	"path/filepath"
 	"strings"
 )
 
@@ -38,12 +37,31 @@ type Package struct {
 	Imports    []string     `json:",omitempty"`
 	Funcs      []*Function  `json:",omitempty"`
 	Interfaces []*Interface `json:",omitempty"`
+	Structs    []*Struct    `json:",omitempty"`
+
+	// TypeErrors holds any errors reported by the go/types checker when
+	// this package was parsed by ParsePackagesTypeChecked. It's empty
+	// for packages parsed by ParsePackagesFromDir.
+	TypeErrors []string `json:",omitempty"`
+
+	// scope is the package-level *types.Scope resolved by
+	// ParsePackagesTypeChecked, used by Implementers. It's nil for
+	// packages parsed by ParsePackagesFromDir.
+	scope *types.Scope
 }
 
 type Interface struct {
 	Name       string   `json:"Name"`
 	Methods    []string `json:",omitempty"`
 	Interfaces []string `json:",omitempty"`
+
+	// Pos is the "file:line:column" position of the interface's declaration.
+	Pos string `json:",omitempty"`
+
+	// Object is the interface's resolved types.Object, set when this
+	// Interface was parsed by ParsePackagesTypeChecked. It's nil
+	// otherwise.
+	Object types.Object `json:"-"`
 }
 
 // A Function describes a function.
@@ -54,6 +72,22 @@ type Function struct {
 	Name          string `json:"Name"`
 	Signature     string `json:"Sig"`
 	Documentation string `json:"Doc,omitempty"`
+
+	// Pos is the "file:line:column" position of the function's declaration.
+	Pos string `json:",omitempty"`
+
+	// Complexity is the function's approximate cyclomatic complexity,
+	// computed by counting the branching constructs in its body.
+	Complexity int `json:",omitempty"`
+
+	// Object is the function's resolved types.Object, set when this
+	// Function was parsed by ParsePackagesTypeChecked. It's nil
+	// otherwise.
+	Object types.Object `json:"-"`
+
+	// uses holds the objects referenced by the function's body, set
+	// alongside Object. Exposed through the Uses method.
+	uses []types.Object
 }
 
 // IsExported is a wrapper around ast.IsExported that returns a true or false
@@ -62,7 +96,13 @@ func (f *Function) IsExported() bool {
 	return ast.IsExported(f.Name)
 }
 
-// ParsePackagesFromDir parses all packages in a directory.
+// ParsePackagesFromDir parses all packages found under dir, honoring
+// build constraints (//go:build, GOOS/GOARCH file suffixes) so that,
+// e.g., linux- and windows-only files in the same directory no longer
+// get merged into one bogus package. vendor and testdata directories
+// are skipped. The returned map is keyed by each package's path
+// relative to dir rather than by package name, since directory trees
+// commonly contain several distinct "main" or same-named packages.
 //
 // If ignoreTests is true, all test files will be ignored.
 //
@@ -72,40 +112,14 @@ func (f *Function) IsExported() bool {
 // If an error occurs whilst traversing the nested directories,
 // ParsePackagesFromDir will return a map containing any correctly
 // parsed packages and the error that occurred.
+//
+// ParsePackagesFromDir has no notion of a module path: packages are
+// keyed by their path relative to dir. Use ParsePackagesFromModule when
+// parsing a real module and true import paths matter.
 func ParsePackagesFromDir(dir string, ignoreTests bool, funcOption FuncOption) (map[string]*Package, error) {
-	fset := token.NewFileSet()
-
-	pkgs := make(map[string]*Package)
-
-	var filter func(os.FileInfo) bool
-	if ignoreTests {
-		filter = FilterIgnoreTests
-	}
-
-	return pkgs, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if !info.IsDir() || strings.HasPrefix(path, filepath.Join(dir, "cmd")) {
-			return nil
-		}
-
-		parsed, err := parser.ParseDir(fset, path, filter, parser.ParseComments)
-		if err != nil {
-			return err
-		}
-
-		for _, pkg := range parsed {
-			p := ParsePackage(fset, pkg, funcOption)
-			if _, exists := pkgs[pkg.Name]; exists {
-				pkgs[pkg.Name].Funcs = append(pkgs[pkg.Name].Funcs, p.Funcs...)
-			} else {
-				pkgs[pkg.Name] = p
-			}
-		}
-
-		return nil
+	return parsePackagesFromDir(dir, "", ModuleOptions{
+		IgnoreTests: ignoreTests,
+		FuncOption:  funcOption,
 	})
 }
 
@@ -123,6 +137,7 @@ func ParsePackage(fset *token.FileSet, pkg *ast.Package, funcOption FuncOption)
 		Funcs:      ParseFileFuncs(fset, mergedFile, funcOption),
 		Imports:    ParseFileImports(mergedFile),
 		Interfaces: ParseFileInterfaces(fset, mergedFile),
+		Structs:    ParseFileStructs(fset, mergedFile),
 	}
 }
 
@@ -158,7 +173,11 @@ func ParseFileFuncs(fset *token.FileSet, file *ast.File, funcOption FuncOption)
 
 // ParseFunction returns a []*Function generated from an *ast.FuncDecl.
 func ParseFunction(fset *token.FileSet, fnc *ast.FuncDecl, bb *bytes.Buffer) *Function {
-	f := &Function{Name: fnc.Name.Name}
+	f := &Function{
+		Name:       fnc.Name.Name,
+		Pos:        fset.Position(fnc.Pos()).String(),
+		Complexity: complexity(fnc.Body),
+	}
 
 	fnc.Body = nil
 
@@ -177,6 +196,31 @@ func ParseFunction(fset *token.FileSet, fnc *ast.FuncDecl, bb *bytes.Buffer) *Fu
 	return f
 }
 
+// complexity returns the approximate cyclomatic complexity of body,
+// counting its branching constructs (if, for, range, case, &&, ||) plus
+// one for the function's single entry point. It returns 0 for a nil
+// body, e.g. an interface method or a function declared without one.
+func complexity(body *ast.BlockStmt) int {
+	if body == nil {
+		return 0
+	}
+
+	c := 1
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.CaseClause, *ast.CommClause:
+			c++
+		case *ast.BinaryExpr:
+			if stmt.Op == token.LAND || stmt.Op == token.LOR {
+				c++
+			}
+		}
+		return true
+	})
+
+	return c
+}
+
 // ParseFileImports generates a list of imports from an *ast.File object.
 func ParseFileImports(file *ast.File) []string {
 	imports := []string{}
@@ -209,7 +253,11 @@ func ParseFileInterfaces(fset *token.FileSet, file *ast.File) []*Interface {
 				continue
 			}
 
-			iface := &Interface{Name: ts.Name.Name, Methods: []string{}}
+			iface := &Interface{
+				Name:    ts.Name.Name,
+				Methods: []string{},
+				Pos:     fset.Position(ts.Pos()).String(),
+			}
 			list := ifaceType.Methods.List
 			for _, names := range list {
 				ident, ok := names.Type.(*ast.Ident)
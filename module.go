@@ -0,0 +1,156 @@
+package inspect
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ModuleOptions configures ParsePackagesFromModule and the internal
+// directory walk shared with ParsePackagesFromDir.
+type ModuleOptions struct {
+	// IgnoreTests, when true, excludes _test.go files from the parse.
+	IgnoreTests bool
+
+	// FuncOption controls which functions are retained, exactly as in
+	// ParsePackagesFromDir.
+	FuncOption FuncOption
+
+	// Context selects the build constraints (GOOS, GOARCH, build tags)
+	// used to decide which files in a directory belong to its package.
+	// If nil, build.Default is used.
+	Context *build.Context
+}
+
+// ParsePackagesFromModule parses every package under modRoot, a
+// directory containing a go.mod file, and returns them keyed by their
+// real import path (the module's declared path joined with each
+// package's path relative to modRoot).
+//
+// It's the modern entry point for parsing a module: unlike
+// ParsePackagesFromDir, the returned keys are genuine import paths, so
+// a package imported elsewhere in the module can be looked up
+// directly.
+func ParsePackagesFromModule(modRoot string, opts ModuleOptions) (map[string]*Package, error) {
+	modPath, err := moduleDeclaredPath(modRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePackagesFromDir(modRoot, modPath, opts)
+}
+
+// moduleDeclaredPath returns the module path declared by the "module"
+// directive in modRoot's go.mod file.
+func moduleDeclaredPath(modRoot string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(modRoot, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module")), nil
+		}
+	}
+
+	return "", &build.NoGoError{Dir: modRoot}
+}
+
+// parsePackagesFromDir walks dir, building a *Package for every
+// directory that contains a buildable Go package under opts.Context,
+// and keys the result by joining importPrefix with each package's path
+// relative to dir.
+func parsePackagesFromDir(dir, importPrefix string, opts ModuleOptions) (map[string]*Package, error) {
+	fset := token.NewFileSet()
+
+	pkgs := make(map[string]*Package)
+
+	ctxt := opts.Context
+	if ctxt == nil {
+		defCtxt := build.Default
+		ctxt = &defCtxt
+	}
+
+	return pkgs, filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		if name := info.Name(); name == "vendor" || name == "testdata" ||
+			(name != "." && strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+
+		bp, err := ctxt.ImportDir(p, 0)
+		if err != nil {
+			if _, ok := err.(*build.NoGoError); ok {
+				return nil
+			}
+			return err
+		}
+
+		astPkg, err := buildASTPackage(fset, bp, opts.IgnoreTests)
+		if err != nil {
+			return err
+		}
+
+		pkg := ParsePackage(fset, astPkg, opts.FuncOption)
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		pkgs[joinImportPath(importPrefix, rel)] = pkg
+
+		return nil
+	})
+}
+
+// buildASTPackage parses the Go source files that build.Context chose
+// for bp, returning them as an *ast.Package suitable for ParsePackage.
+func buildASTPackage(fset *token.FileSet, bp *build.Package, ignoreTests bool) (*ast.Package, error) {
+	names := append([]string{}, bp.GoFiles...)
+	if !ignoreTests {
+		names = append(names, bp.TestGoFiles...)
+		names = append(names, bp.XTestGoFiles...)
+	}
+
+	files := make(map[string]*ast.File, len(names))
+	for _, name := range names {
+		full := filepath.Join(bp.Dir, name)
+		f, err := parser.ParseFile(fset, full, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files[full] = f
+	}
+
+	return &ast.Package{Name: bp.Name, Files: files}, nil
+}
+
+// joinImportPath joins an import path prefix with rel, a slash- or
+// OS-separator-delimited path relative to the directory being walked.
+func joinImportPath(prefix, rel string) string {
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		if prefix == "" {
+			return "."
+		}
+		return prefix
+	}
+	if prefix == "" {
+		return rel
+	}
+	return path.Join(prefix, rel)
+}
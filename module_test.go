@@ -0,0 +1,35 @@
+package inspect
+
+import "testing"
+
+func TestParsePackagesFromDirKeyedByImportPath(t *testing.T) {
+	pkgs, err := ParsePackagesFromDir("testfiles", true, FuncBoth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, ok := pkgs["structs"]
+	if !ok {
+		t.Fatalf("expected a package keyed by %q, got keys %v", "structs", keys(pkgs))
+	}
+
+	if pkg.Name != "structs" {
+		t.Errorf("expected package name structs, got %s", pkg.Name)
+	}
+
+	if len(pkg.Structs) != 2 {
+		t.Errorf("expected 2 structs, found %d", len(pkg.Structs))
+	}
+
+	if _, ok := pkgs["."]; !ok {
+		t.Fatalf("expected the root testfiles package to be keyed by %q, got keys %v", ".", keys(pkgs))
+	}
+}
+
+func keys(pkgs map[string]*Package) []string {
+	ks := make([]string, 0, len(pkgs))
+	for k := range pkgs {
+		ks = append(ks, k)
+	}
+	return ks
+}
@@ -0,0 +1,257 @@
+package inspect
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ParsePackagesFromDirCached behaves like ParsePackagesFromDir, except
+// that it builds each file's fragment (its Funcs, Interfaces and
+// Struct field lists) one file at a time, consulting cache before
+// redoing that work: a file whose content hash (combined with
+// funcOption) is already present in cache has its fragment served
+// straight from there instead of rebuilt. Every file is still parsed
+// to an *ast.File regardless of cache hits, since a struct's method
+// set can span files and resolving it needs every file in the package.
+// Cached and freshly-built fragments from the same directory are
+// merged into that directory's Package.
+//
+// Independent directories are parsed concurrently with a worker pool
+// bounded by runtime.NumCPU, since parsing is CPU-bound and each
+// directory's result doesn't depend on any other's. A nil cache is
+// allowed and simply disables caching, parsing every file every call.
+func ParsePackagesFromDirCached(dir string, ignoreTests bool, funcOption FuncOption, cache Cache) (map[string]*Package, error) {
+	jobs, err := buildPackageJobs(dir, ignoreTests)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+
+	workers := runtime.NumCPU()
+	if workers > len(jobs) || workers < 1 {
+		workers = len(jobs)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobCh := make(chan buildPackageJob)
+	type jobResult struct {
+		importPath string
+		pkg        *Package
+		err        error
+	}
+	resCh := make(chan jobResult)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobCh {
+				pkg, err := parseBuildPackageCached(fset, j.bp, ignoreTests, funcOption, cache)
+				resCh <- jobResult{importPath: j.importPath, pkg: pkg, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for _, j := range jobs {
+			jobCh <- j
+		}
+		close(jobCh)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resCh)
+	}()
+
+	pkgs := make(map[string]*Package, len(jobs))
+	var firstErr error
+	for res := range resCh {
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			continue
+		}
+		pkgs[res.importPath] = res.pkg
+	}
+
+	return pkgs, firstErr
+}
+
+// buildPackageJob is one directory's worth of work: the *build.Package
+// describing which files belong to it, and the import path it should
+// be keyed by in the result map.
+type buildPackageJob struct {
+	importPath string
+	bp         *build.Package
+}
+
+// buildPackageJobs walks dir exactly like parsePackagesFromDir, but
+// only resolves each directory's *build.Package; it doesn't parse any
+// files, so the resulting jobs can be handed out to worker goroutines.
+func buildPackageJobs(dir string, ignoreTests bool) ([]buildPackageJob, error) {
+	ctxt := build.Default
+
+	var jobs []buildPackageJob
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		if name := info.Name(); name == "vendor" || name == "testdata" ||
+			(name != "." && strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+
+		bp, err := ctxt.ImportDir(p, 0)
+		if err != nil {
+			if _, ok := err.(*build.NoGoError); ok {
+				return nil
+			}
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		jobs = append(jobs, buildPackageJob{importPath: joinImportPath("", rel), bp: bp})
+
+		return nil
+	})
+
+	return jobs, err
+}
+
+// parseBuildPackageCached parses every file belonging to bp, fetching
+// each one's fragment from cache where possible, and merges the
+// results into a single Package.
+//
+// A struct's methods can live in a different file from its type
+// declaration, so resolving Struct.Methods can't be done per file the
+// way the rest of a fragment can: it needs every file in the package
+// parsed, cache hits included. parseFileCached returns each file's
+// *ast.File alongside its fragment for exactly that reason, and
+// resolveMethodSets fills in Methods afterwards, once every file in bp
+// has been parsed.
+func parseBuildPackageCached(fset *token.FileSet, bp *build.Package, ignoreTests bool, funcOption FuncOption, cache Cache) (*Package, error) {
+	names := append([]string{}, bp.GoFiles...)
+	if !ignoreTests {
+		names = append(names, bp.TestGoFiles...)
+		names = append(names, bp.XTestGoFiles...)
+	}
+
+	files := make([]*ast.File, 0, len(names))
+	frags := make([]*Package, 0, len(names))
+	for _, name := range names {
+		file, frag, err := parseFileCached(fset, filepath.Join(bp.Dir, name), funcOption, cache)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+		frags = append(frags, frag)
+	}
+
+	merged := mergeFragments(bp.Name, frags)
+	resolveMethodSets(fset, files, merged.Structs)
+
+	return merged, nil
+}
+
+// parseFileCached returns the file at path parsed into an *ast.File,
+// along with its fragment, served from cache when the file's content
+// hash and funcOption match a stored entry, and parsed and stored
+// otherwise. The *ast.File is always returned, even on a cache hit,
+// since resolving a package's struct methods requires every file's AST.
+func parseFileCached(fset *token.FileSet, path string, funcOption FuncOption, cache Cache) (*ast.File, *Package, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := parser.ParseFile(fset, path, data, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := fragmentKey(data, funcOption)
+	if cache != nil {
+		if frag, ok := cache.Get(key); ok {
+			return file, frag, nil
+		}
+	}
+
+	frag := parseFileFragment(fset, file, funcOption)
+
+	if cache != nil {
+		if err := cache.Put(key, frag); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return file, frag, nil
+}
+
+// fragmentKey derives a Cache key from a file's content and the
+// FuncOption it's parsed with, so a later call with the same content
+// but a different FuncOption doesn't return a stale fragment.
+func fragmentKey(data []byte, funcOption FuncOption) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%x-%d", sum, funcOption)
+}
+
+// parseFileFragment parses a single file's worth of Package data,
+// suitable for caching and later merging with its package's other
+// files via mergeFragments.
+func parseFileFragment(fset *token.FileSet, file *ast.File, funcOption FuncOption) *Package {
+	return &Package{
+		Name:       file.Name.Name,
+		Imports:    ParseFileImports(file),
+		Funcs:      ParseFileFuncs(fset, file, funcOption),
+		Interfaces: ParseFileInterfaces(fset, file),
+		Structs:    ParseFileStructs(fset, file),
+	}
+}
+
+// mergeFragments concatenates a package's per-file fragments into a
+// single Package, deduplicating imports along the way. The resulting
+// Structs still carry only the Methods visible from their own file;
+// callers that need a struct's full cross-file method set should
+// follow up with resolveMethodSets.
+func mergeFragments(name string, frags []*Package) *Package {
+	merged := &Package{Name: name}
+
+	seenImports := make(map[string]bool)
+	for _, frag := range frags {
+		for _, imp := range frag.Imports {
+			if !seenImports[imp] {
+				seenImports[imp] = true
+				merged.Imports = append(merged.Imports, imp)
+			}
+		}
+		merged.Funcs = append(merged.Funcs, frag.Funcs...)
+		merged.Interfaces = append(merged.Interfaces, frag.Interfaces...)
+		merged.Structs = append(merged.Structs, frag.Structs...)
+	}
+
+	return merged
+}
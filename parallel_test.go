@@ -0,0 +1,94 @@
+package inspect
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParsePackagesFromDirCached(t *testing.T) {
+	dir, err := os.MkdirTemp("", "inspect-cache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs, err := ParsePackagesFromDirCached("testfiles", true, FuncBoth, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, ok := pkgs["structs"]
+	if !ok {
+		t.Fatalf("expected a package keyed by %q, got keys %v", "structs", keys(pkgs))
+	}
+	if len(pkg.Structs) != 2 {
+		t.Errorf("expected 2 structs, found %d", len(pkg.Structs))
+	}
+
+	// Widget's methods are split across struct1.go and struct2.go, so
+	// this only passes if struct method resolution sees every file in
+	// the package, not just the one a struct is declared in.
+	widget := findStruct(pkg.Structs, "Widget")
+	if widget == nil {
+		t.Fatal("expected a Widget struct")
+	}
+	if len(widget.Methods) != 2 {
+		t.Errorf("expected Widget to have 2 methods spanning both files, found %d: %v", len(widget.Methods), widget.Methods)
+	}
+
+	// A second, warm run should return the same data, served from cache.
+	pkgs2, err := ParsePackagesFromDirCached("testfiles", true, FuncBoth, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs2["structs"].Structs) != len(pkg.Structs) {
+		t.Errorf("warm run returned %d structs, expected %d", len(pkgs2["structs"].Structs), len(pkg.Structs))
+	}
+	if warmWidget := findStruct(pkgs2["structs"].Structs, "Widget"); warmWidget == nil || len(warmWidget.Methods) != 2 {
+		t.Errorf("warm run: expected Widget to still have 2 methods, got %v", warmWidget)
+	}
+}
+
+func findStruct(structs []*Struct, name string) *Struct {
+	for _, s := range structs {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func TestDiskCacheRoundTrip(t *testing.T) {
+	dir, err := os.MkdirTemp("", "inspect-diskcache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frag := &Package{Name: "demo", Funcs: []*Function{{Name: "Foo"}}}
+	if err := cache.Put("somekey", frag); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := cache.Get("somekey")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if got.Name != "demo" || len(got.Funcs) != 1 || got.Funcs[0].Name != "Foo" {
+		t.Errorf("unexpected round-tripped fragment: %+v", got)
+	}
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("expected cache miss for unknown key")
+	}
+}
@@ -0,0 +1,168 @@
+package inspect
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// A Struct describes a struct type.
+//
+// A Struct contains the struct's name, its non-embedded fields, the
+// names of any embedded types (listed separately from Fields), and the
+// method set attached to it via receiver declarations found elsewhere
+// in the package.
+type Struct struct {
+	Name     string   `json:"Name"`
+	Fields   []*Field `json:",omitempty"`
+	Embedded []string `json:",omitempty"`
+	Methods  []string `json:",omitempty"`
+
+	// Pos is the "file:line:column" position of the struct's declaration.
+	Pos string `json:",omitempty"`
+}
+
+// A Field describes a single field of a Struct.
+type Field struct {
+	Name          string `json:"Name"`
+	Type          string `json:"Type"`
+	Tag           string `json:"Tag,omitempty"`
+	Documentation string `json:"Doc,omitempty"`
+}
+
+// structRecv matches the leading "func (recv Type) " receiver clause
+// printed ahead of a method's name, so it can be stripped when
+// comparing a Struct's method set against an Interface's.
+var structRecv = regexp.MustCompile(`^func \([^)]*\)\s*`)
+
+// Implements reports whether s's method set contains every method
+// declared by iface, comparing method signatures with receivers
+// stripped. It's a purely textual, AST-level comparison: it doesn't
+// resolve embedded interfaces or promoted methods from embedded
+// structs.
+func (s *Struct) Implements(iface *Interface) bool {
+	if len(iface.Methods) == 0 {
+		return false
+	}
+
+	have := make(map[string]bool, len(s.Methods))
+	for _, m := range s.Methods {
+		have[structRecv.ReplaceAllString(m, "func ")] = true
+	}
+
+	for _, m := range iface.Methods {
+		if !have[m] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseFileStructs generates a []*Struct from an *ast.File object.
+func ParseFileStructs(fset *token.FileSet, file *ast.File) []*Struct {
+	structs := []*Struct{}
+
+	var bb bytes.Buffer
+	ast.Inspect(file, func(n ast.Node) bool {
+		decl, ok := n.(*ast.GenDecl)
+		if !ok {
+			return true
+		}
+		for _, spec := range decl.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			structType, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			s := &Struct{Name: ts.Name.Name, Pos: fset.Position(ts.Pos()).String()}
+
+			for _, field := range structType.Fields.List {
+				bb.Reset()
+				printer.Fprint(&bb, fset, field.Type)
+				typ := bb.String()
+				bb.Reset()
+
+				if len(field.Names) == 0 {
+					s.Embedded = append(s.Embedded, typ)
+					continue
+				}
+
+				var tag string
+				if field.Tag != nil {
+					tag = strings.Trim(field.Tag.Value, "`")
+				}
+
+				for _, name := range field.Names {
+					s.Fields = append(s.Fields, &Field{
+						Name:          name.Name,
+						Type:          typ,
+						Tag:           tag,
+						Documentation: strings.TrimSpace(field.Doc.Text()),
+					})
+				}
+			}
+
+			s.Methods = parseStructMethods(fset, file, ts.Name.Name, &bb)
+
+			structs = append(structs, s)
+		}
+		return true
+	})
+
+	return structs
+}
+
+// resolveMethodSets fills in each of structs' Methods by scanning
+// every file in files for receiver declarations, rather than just the
+// one file a struct happens to be declared in. It's used by the cached
+// parse path, which parses (and may cache) one file at a time and so
+// can't rely on ParseFileStructs alone to see a method declared in a
+// different file from its struct's type declaration.
+func resolveMethodSets(fset *token.FileSet, files []*ast.File, structs []*Struct) {
+	var bb bytes.Buffer
+	for _, s := range structs {
+		var methods []string
+		for _, file := range files {
+			methods = append(methods, parseStructMethods(fset, file, s.Name, &bb)...)
+		}
+		s.Methods = methods
+	}
+}
+
+// parseStructMethods returns the signatures of every method declared
+// on structName within file, in the same format as Function.Signature.
+func parseStructMethods(fset *token.FileSet, file *ast.File, structName string, bb *bytes.Buffer) []string {
+	methods := []string{}
+
+	for _, decl := range file.Decls {
+		fnc, ok := decl.(*ast.FuncDecl)
+		if !ok || fnc.Recv == nil || len(fnc.Recv.List) == 0 {
+			continue
+		}
+
+		recvType := fnc.Recv.List[0].Type
+		if star, ok := recvType.(*ast.StarExpr); ok {
+			recvType = star.X
+		}
+
+		ident, ok := recvType.(*ast.Ident)
+		if !ok || ident.Name != structName {
+			continue
+		}
+
+		bb.Reset()
+		if f := ParseFunction(fset, fnc, bb); f != nil {
+			methods = append(methods, f.Signature)
+		}
+	}
+
+	return methods
+}
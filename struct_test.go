@@ -0,0 +1,100 @@
+package inspect
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+const structsTestPath = "testfiles/structs/struct1.go"
+
+func TestParseFileStructs(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, structsTestPath, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	structs := ParseFileStructs(fset, file)
+	if len(structs) != 2 {
+		t.Fatalf("expected 2 structs, found %d", len(structs))
+	}
+
+	widget := structs[0]
+	if widget.Name != "Widget" {
+		t.Errorf("expected first struct to be Widget, got %s", widget.Name)
+	}
+
+	if len(widget.Fields) != 2 {
+		t.Fatalf("expected 2 fields, found %d", len(widget.Fields))
+	}
+
+	if widget.Fields[0].Name != "Name" || widget.Fields[0].Type != "string" {
+		t.Errorf("unexpected field: %+v", widget.Fields[0])
+	}
+
+	if widget.Fields[0].Tag != `json:"name"` {
+		t.Errorf("expected tag `json:\"name\"`, got %q", widget.Fields[0].Tag)
+	}
+
+	if widget.Fields[0].Documentation != "Name is the widget's name." {
+		t.Errorf("unexpected field doc: %q", widget.Fields[0].Documentation)
+	}
+
+	if len(widget.Embedded) != 1 || widget.Embedded[0] != "Labeled" {
+		t.Errorf("expected Widget to embed Labeled, got %v", widget.Embedded)
+	}
+
+	if len(widget.Methods) != 1 {
+		t.Fatalf("expected 1 method, found %d", len(widget.Methods))
+	}
+}
+
+func TestStructImplements(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, structsTestPath, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	structs := ParseFileStructs(fset, file)
+	ifaces := ParseFileInterfaces(fset, file)
+
+	var widget *Struct
+	for _, s := range structs {
+		if s.Name == "Widget" {
+			widget = s
+		}
+	}
+	if widget == nil {
+		t.Fatal("Widget struct not found")
+	}
+
+	var reader *Interface
+	for _, i := range ifaces {
+		if i.Name == "Reader" {
+			reader = i
+		}
+	}
+	if reader == nil {
+		t.Fatal("Reader interface not found")
+	}
+
+	if !widget.Implements(reader) {
+		t.Error("expected Widget to implement Reader")
+	}
+
+	var labeled *Struct
+	for _, s := range structs {
+		if s.Name == "Labeled" {
+			labeled = s
+		}
+	}
+	if labeled == nil {
+		t.Fatal("Labeled struct not found")
+	}
+
+	if labeled.Implements(reader) {
+		t.Error("expected Labeled to not implement Reader")
+	}
+}
@@ -0,0 +1,32 @@
+package structs
+
+// Reader is implemented by types that can read a name.
+type Reader interface {
+	Read() string
+}
+
+// Loner has no implementers in this package, to exercise
+// InterfaceWithNoImplementers' positive case.
+type Loner interface {
+	Alone() string
+}
+
+// Widget is a struct used to exercise struct parsing.
+type Widget struct {
+	// Name is the widget's name.
+	Name string `json:"name"`
+
+	Count int
+
+	Labeled
+}
+
+// Labeled is embedded by Widget.
+type Labeled struct {
+	Label string
+}
+
+// Read returns the widget's name.
+func (w *Widget) Read() string {
+	return w.Name
+}
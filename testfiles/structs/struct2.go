@@ -0,0 +1,7 @@
+package structs
+
+// String returns a human-readable representation of the widget, to
+// exercise a struct whose method set spans more than one file.
+func (w *Widget) String() string {
+	return w.Name
+}
@@ -0,0 +1,8 @@
+package structs
+
+// Read is a free function that happens to share its name with
+// (*Widget).Read, to exercise that type-checked Object resolution is
+// keyed by declaration rather than by name.
+func Read() string {
+	return "free"
+}
@@ -0,0 +1,230 @@
+package inspect
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TypeCheckOptions configures the semantic, type-checked parsing pass
+// performed by ParsePackagesTypeChecked.
+type TypeCheckOptions struct {
+	// IgnoreTests, when true, excludes _test.go files from both the
+	// AST and type-checking passes.
+	IgnoreTests bool
+
+	// FuncOption controls which functions are retained, exactly as in
+	// ParsePackagesFromDir.
+	FuncOption FuncOption
+
+	// Importer resolves imported packages during type-checking. If nil,
+	// importer.Default() is used.
+	Importer types.Importer
+}
+
+// ParsePackagesTypeChecked walks dir exactly like ParsePackagesFromDir
+// (same directory-skip rules, same import-path keying), but
+// additionally runs every discovered package through the go/types
+// checker. Doing so attaches a resolved types.Object to each parsed
+// Function and Interface, and unlocks the semantic queries that the
+// AST-only pipeline can't answer, such as Package.Implementers and
+// Function.Uses.
+//
+// A package that fails to type-check (most commonly because one of its
+// imports can't be resolved by opts.Importer) is still returned with
+// its AST-only data populated, its checker errors recorded in
+// TypeErrors, and Object references for whatever the checker managed
+// to resolve; Objects it couldn't resolve are left nil.
+func ParsePackagesTypeChecked(dir string, opts TypeCheckOptions) (map[string]*Package, error) {
+	fset := token.NewFileSet()
+
+	imp := opts.Importer
+	if imp == nil {
+		imp = importer.Default()
+	}
+
+	ctxt := build.Default
+
+	pkgs := make(map[string]*Package)
+
+	return pkgs, filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		if name := info.Name(); name == "vendor" || name == "testdata" ||
+			(name != "." && strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+
+		bp, err := ctxt.ImportDir(p, 0)
+		if err != nil {
+			if _, ok := err.(*build.NoGoError); ok {
+				return nil
+			}
+			return err
+		}
+
+		astPkg, err := buildASTPackage(fset, bp, opts.IgnoreTests)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		pkgs[joinImportPath("", rel)] = parsePackageTypeChecked(fset, astPkg, p, opts.FuncOption, imp)
+
+		return nil
+	})
+}
+
+// parsePackageTypeChecked type-checks astPkg's files before handing them
+// to ParsePackage, so that function bodies are still intact when the
+// type-checker records its Uses information.
+func parsePackageTypeChecked(fset *token.FileSet, astPkg *ast.Package, path string, funcOption FuncOption, imp types.Importer) *Package {
+	files := make([]*ast.File, 0, len(astPkg.Files))
+	for _, f := range astPkg.Files {
+		files = append(files, f)
+	}
+
+	info := &types.Info{
+		Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+
+	var typeErrs []string
+	conf := types.Config{
+		Importer: imp,
+		Error:    func(err error) { typeErrs = append(typeErrs, err.Error()) },
+	}
+	tpkg, _ := conf.Check(path, fset, files, info)
+
+	// Funcs is keyed by a decl's file:line position, not its bare name:
+	// pkg.Funcs includes methods alongside free functions, and two
+	// methods on different receivers (or a method and a free function)
+	// can share a name, so a name-keyed map would misattribute Objects
+	// between them.
+	funcObjs := make(map[string]types.Object)
+	funcUses := make(map[string][]types.Object)
+	ifaceObjs := make(map[string]types.Object)
+
+	if tpkg != nil {
+		for _, f := range files {
+			for _, decl := range f.Decls {
+				fnc, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+				pos := fset.Position(fnc.Pos()).String()
+
+				if obj := info.Defs[fnc.Name]; obj != nil {
+					funcObjs[pos] = obj
+				}
+
+				var uses []types.Object
+				if fnc.Body != nil {
+					ast.Inspect(fnc.Body, func(n ast.Node) bool {
+						if id, ok := n.(*ast.Ident); ok {
+							if u, ok := info.Uses[id]; ok {
+								uses = append(uses, u)
+							}
+						}
+						return true
+					})
+				}
+				funcUses[pos] = uses
+			}
+
+			ast.Inspect(f, func(n ast.Node) bool {
+				ts, ok := n.(*ast.TypeSpec)
+				if !ok {
+					return true
+				}
+				if _, ok := ts.Type.(*ast.InterfaceType); !ok {
+					return true
+				}
+				if obj := info.Defs[ts.Name]; obj != nil {
+					ifaceObjs[ts.Name.Name] = obj
+				}
+				return true
+			})
+		}
+	}
+
+	// Now that the type-checker has seen every function body, it's safe
+	// to let ParsePackage merge the files and strip the bodies it
+	// doesn't need.
+	p := ParsePackage(fset, astPkg, funcOption)
+
+	if tpkg != nil {
+		p.scope = tpkg.Scope()
+	}
+	p.TypeErrors = typeErrs
+
+	for _, fn := range p.Funcs {
+		fn.Object = funcObjs[fn.Pos]
+		fn.uses = funcUses[fn.Pos]
+	}
+	for _, ifc := range p.Interfaces {
+		ifc.Object = ifaceObjs[ifc.Name]
+	}
+
+	return p
+}
+
+// TypeChecked reports whether p was produced by
+// ParsePackagesTypeChecked. Packages parsed by ParsePackagesFromDir or
+// ParsePackagesFromDirCached carry no type information, so callers that
+// depend on Implementers or Function.Uses should check this first.
+func (p *Package) TypeChecked() bool {
+	return p.scope != nil
+}
+
+// Implementers returns the names of every named type in p that
+// satisfies iface, according to the type-checked information attached
+// by ParsePackagesTypeChecked. It returns nil if p wasn't produced by
+// ParsePackagesTypeChecked, or if iface carries no resolved Object.
+func (p *Package) Implementers(iface *Interface) []string {
+	if p.scope == nil || iface.Object == nil {
+		return nil
+	}
+
+	ifaceType, ok := iface.Object.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, name := range p.scope.Names() {
+		tn, ok := p.scope.Lookup(name).(*types.TypeName)
+		if !ok || tn == iface.Object {
+			continue
+		}
+
+		t := tn.Type()
+		if types.Implements(t, ifaceType) || types.Implements(types.NewPointer(t), ifaceType) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Uses returns the resolved objects referenced by the function's body,
+// as recorded by the type-checker. It returns nil if f wasn't produced
+// by ParsePackagesTypeChecked.
+func (f *Function) Uses() []types.Object {
+	return f.uses
+}
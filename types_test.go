@@ -0,0 +1,122 @@
+package inspect
+
+import (
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func TestParsePackagesTypeChecked(t *testing.T) {
+	pkgs, err := ParsePackagesTypeChecked("testfiles", TypeCheckOptions{
+		IgnoreTests: true,
+		FuncOption:  FuncBoth,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, ok := pkgs["."]
+	if !ok {
+		t.Fatalf("expected the root testfiles package to be keyed by %q, got keys %v", ".", keys(pkgs))
+	}
+
+	if len(pkg.Funcs) == 0 {
+		t.Fatal("expected at least one function")
+	}
+
+	var found bool
+	for _, fnc := range pkg.Funcs {
+		if fnc.Name != tf1FuncName {
+			continue
+		}
+		found = true
+		if fnc.Object == nil {
+			t.Errorf("expected %s to have a resolved Object", tf1FuncName)
+		}
+	}
+	if !found {
+		t.Fatalf("function %s not found", tf1FuncName)
+	}
+}
+
+func TestImplementersExcludesTheInterfaceItself(t *testing.T) {
+	pkgs, err := ParsePackagesTypeChecked("testfiles/structs", TypeCheckOptions{
+		IgnoreTests: true,
+		FuncOption:  FuncBoth,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, ok := pkgs["."]
+	if !ok {
+		t.Fatalf("expected testfiles/structs to be keyed by %q, got keys %v", ".", keys(pkgs))
+	}
+
+	var reader, loner *Interface
+	for _, ifc := range pkg.Interfaces {
+		switch ifc.Name {
+		case "Reader":
+			reader = ifc
+		case "Loner":
+			loner = ifc
+		}
+	}
+	if reader == nil || loner == nil {
+		t.Fatalf("expected Reader and Loner interfaces, got %+v", pkg.Interfaces)
+	}
+
+	if impls := pkg.Implementers(reader); len(impls) != 1 || impls[0] != "Widget" {
+		t.Errorf("expected Implementers(Reader) = [Widget], got %v", impls)
+	}
+
+	// Loner has no implementer in this package; Implementers must not
+	// report Loner itself as satisfying its own interface.
+	if impls := pkg.Implementers(loner); len(impls) != 0 {
+		t.Errorf("expected Implementers(Loner) = [], got %v", impls)
+	}
+}
+
+func TestParsePackagesTypeCheckedDisambiguatesSameNamedDecls(t *testing.T) {
+	pkgs, err := ParsePackagesTypeChecked("testfiles/structs", TypeCheckOptions{
+		IgnoreTests: true,
+		FuncOption:  FuncBoth,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, ok := pkgs["."]
+	if !ok {
+		t.Fatalf("expected testfiles/structs to be keyed by %q, got keys %v", ".", keys(pkgs))
+	}
+
+	var method, freeFunc *Function
+	for _, fn := range pkg.Funcs {
+		if fn.Name != "Read" {
+			continue
+		}
+		if strings.Contains(fn.Signature, "Widget") {
+			method = fn
+		} else {
+			freeFunc = fn
+		}
+	}
+	if method == nil || freeFunc == nil {
+		t.Fatalf("expected both (*Widget).Read and the free Read function, got method=%v free=%v", method, freeFunc)
+	}
+
+	if method.Object == nil || freeFunc.Object == nil {
+		t.Fatal("expected both same-named Read decls to have a resolved Object")
+	}
+
+	methodSig, ok := method.Object.Type().(*types.Signature)
+	if !ok || methodSig.Recv() == nil {
+		t.Errorf("expected (*Widget).Read's Object to be a method, got %v", method.Object)
+	}
+
+	freeSig, ok := freeFunc.Object.Type().(*types.Signature)
+	if !ok || freeSig.Recv() != nil {
+		t.Errorf("expected the free Read's Object to have no receiver, got %v", freeFunc.Object)
+	}
+}